@@ -0,0 +1,201 @@
+package builder
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// HTTPRouteBuilder is a builder for gateway api HTTPRoute.
+// Fields that are not set through the builder are left empty; no gateway API
+// defaults are applied to them.
+// Primarily used for testing.
+type HTTPRouteBuilder struct {
+	httpRoute gatewayv1beta1.HTTPRoute
+}
+
+// NewHTTPRoute returns an HTTPRouteBuilder with the given name.
+func NewHTTPRoute(name string) *HTTPRouteBuilder {
+	return &HTTPRouteBuilder{
+		httpRoute: gatewayv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		},
+	}
+}
+
+// Build returns the configured HTTPRoute.
+func (b *HTTPRouteBuilder) Build() *gatewayv1beta1.HTTPRoute {
+	return &b.httpRoute
+}
+
+// WithNamespace sets the namespace of the HTTPRoute.
+func (b *HTTPRouteBuilder) WithNamespace(namespace string) *HTTPRouteBuilder {
+	b.httpRoute.Namespace = namespace
+	return b
+}
+
+// WithHostnames sets the hostnames the HTTPRoute should match.
+func (b *HTTPRouteBuilder) WithHostnames(hostnames ...string) *HTTPRouteBuilder {
+	for _, hostname := range hostnames {
+		b.httpRoute.Spec.Hostnames = append(b.httpRoute.Spec.Hostnames, gatewayv1beta1.Hostname(hostname))
+	}
+	return b
+}
+
+// WithParentRef adds a parent reference to the HTTPRoute.
+func (b *HTTPRouteBuilder) WithParentRef(name string) *HTTPRouteBuilder {
+	b.httpRoute.Spec.ParentRefs = append(b.httpRoute.Spec.ParentRefs, gatewayv1beta1.ParentReference{
+		Name: gatewayv1beta1.ObjectName(name),
+	})
+	return b
+}
+
+// WithRule adds a rule to the HTTPRoute.
+func (b *HTTPRouteBuilder) WithRule(rule gatewayv1beta1.HTTPRouteRule) *HTTPRouteBuilder {
+	b.httpRoute.Spec.Rules = append(b.httpRoute.Spec.Rules, rule)
+	return b
+}
+
+// HTTPRouteRuleBuilder is a builder for gateway api HTTPRouteRule.
+// Primarily used for testing.
+type HTTPRouteRuleBuilder struct {
+	rule gatewayv1beta1.HTTPRouteRule
+}
+
+// NewHTTPRouteRule returns an HTTPRouteRuleBuilder.
+func NewHTTPRouteRule() *HTTPRouteRuleBuilder {
+	return &HTTPRouteRuleBuilder{}
+}
+
+// Build returns the configured HTTPRouteRule.
+func (b *HTTPRouteRuleBuilder) Build() gatewayv1beta1.HTTPRouteRule {
+	return b.rule
+}
+
+// WithMatch adds an HTTPRouteMatch to the rule.
+func (b *HTTPRouteRuleBuilder) WithMatch(match gatewayv1beta1.HTTPRouteMatch) *HTTPRouteRuleBuilder {
+	b.rule.Matches = append(b.rule.Matches, match)
+	return b
+}
+
+// WithBackendRef adds an HTTPBackendRef to the rule.
+func (b *HTTPRouteRuleBuilder) WithBackendRef(backendRef gatewayv1beta1.HTTPBackendRef) *HTTPRouteRuleBuilder {
+	b.rule.BackendRefs = append(b.rule.BackendRefs, backendRef)
+	return b
+}
+
+// WithFilter adds an HTTPRouteFilter to the rule.
+func (b *HTTPRouteRuleBuilder) WithFilter(filter gatewayv1beta1.HTTPRouteFilter) *HTTPRouteRuleBuilder {
+	b.rule.Filters = append(b.rule.Filters, filter)
+	return b
+}
+
+// HTTPBackendRefBuilder is a builder for gateway api HTTPBackendRef.
+// Primarily used for testing.
+type HTTPBackendRefBuilder struct {
+	backendRef gatewayv1beta1.HTTPBackendRef
+}
+
+// NewHTTPBackendRef returns an HTTPBackendRefBuilder pointing at the given
+// Service name, defaulting the Kind to Service as per the gateway API spec.
+func NewHTTPBackendRef(serviceName string) *HTTPBackendRefBuilder {
+	return &HTTPBackendRefBuilder{
+		backendRef: gatewayv1beta1.HTTPBackendRef{
+			BackendRef: gatewayv1beta1.BackendRef{
+				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+					Kind: addressOf(gatewayv1beta1.Kind("Service")),
+					Name: gatewayv1beta1.ObjectName(serviceName),
+				},
+			},
+		},
+	}
+}
+
+// Build returns the configured HTTPBackendRef.
+func (b *HTTPBackendRefBuilder) Build() gatewayv1beta1.HTTPBackendRef {
+	return b.backendRef
+}
+
+// WithNamespace sets the namespace of the referenced Service.
+func (b *HTTPBackendRefBuilder) WithNamespace(namespace string) *HTTPBackendRefBuilder {
+	b.backendRef.Namespace = addressOf(gatewayv1beta1.Namespace(namespace))
+	return b
+}
+
+// WithPort sets the port of the referenced Service.
+func (b *HTTPBackendRefBuilder) WithPort(port int) *HTTPBackendRefBuilder {
+	b.backendRef.Port = addressOf(gatewayv1beta1.PortNumber(port))
+	return b
+}
+
+// WithWeight sets the weight of the backend ref.
+func (b *HTTPBackendRefBuilder) WithWeight(weight int32) *HTTPBackendRefBuilder {
+	b.backendRef.Weight = addressOf(weight)
+	return b
+}
+
+// WithFilter adds an HTTPRouteFilter scoped to this backend ref.
+func (b *HTTPBackendRefBuilder) WithFilter(filter gatewayv1beta1.HTTPRouteFilter) *HTTPBackendRefBuilder {
+	b.backendRef.Filters = append(b.backendRef.Filters, filter)
+	return b
+}
+
+// HTTPRouteMatchBuilder is a builder for gateway api HTTPRouteMatch.
+// Primarily used for testing.
+type HTTPRouteMatchBuilder struct {
+	match gatewayv1beta1.HTTPRouteMatch
+}
+
+// NewHTTPRouteMatch returns an HTTPRouteMatchBuilder.
+func NewHTTPRouteMatch() *HTTPRouteMatchBuilder {
+	return &HTTPRouteMatchBuilder{}
+}
+
+// Build returns the configured HTTPRouteMatch.
+func (b *HTTPRouteMatchBuilder) Build() gatewayv1beta1.HTTPRouteMatch {
+	return b.match
+}
+
+// WithPathPrefix sets a PathPrefix match on the given path.
+func (b *HTTPRouteMatchBuilder) WithPathPrefix(path string) *HTTPRouteMatchBuilder {
+	b.match.Path = &gatewayv1beta1.HTTPPathMatch{
+		Type:  addressOf(gatewayv1beta1.PathMatchPathPrefix),
+		Value: addressOf(path),
+	}
+	return b
+}
+
+// WithExactPath sets an Exact match on the given path.
+func (b *HTTPRouteMatchBuilder) WithExactPath(path string) *HTTPRouteMatchBuilder {
+	b.match.Path = &gatewayv1beta1.HTTPPathMatch{
+		Type:  addressOf(gatewayv1beta1.PathMatchExact),
+		Value: addressOf(path),
+	}
+	return b
+}
+
+// WithHeaderMatch adds an exact header match to the match.
+func (b *HTTPRouteMatchBuilder) WithHeaderMatch(name, value string) *HTTPRouteMatchBuilder {
+	b.match.Headers = append(b.match.Headers, gatewayv1beta1.HTTPHeaderMatch{
+		Type:  addressOf(gatewayv1beta1.HeaderMatchExact),
+		Name:  gatewayv1beta1.HTTPHeaderName(name),
+		Value: value,
+	})
+	return b
+}
+
+// WithQueryParamMatch adds an exact query parameter match to the match.
+func (b *HTTPRouteMatchBuilder) WithQueryParamMatch(name, value string) *HTTPRouteMatchBuilder {
+	b.match.QueryParams = append(b.match.QueryParams, gatewayv1beta1.HTTPQueryParamMatch{
+		Type:  addressOf(gatewayv1beta1.QueryParamMatchExact),
+		Name:  gatewayv1beta1.HTTPHeaderName(name),
+		Value: value,
+	})
+	return b
+}
+
+// IntoSlice returns the configured HTTPRouteMatch in a slice.
+func (b *HTTPRouteMatchBuilder) IntoSlice() []gatewayv1beta1.HTTPRouteMatch {
+	return []gatewayv1beta1.HTTPRouteMatch{b.match}
+}
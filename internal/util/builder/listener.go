@@ -1,6 +1,10 @@
 package builder
 
-import gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
 
 // ListenerBuilder is a builder for gateway api Listener.
 // Will set default values, as specified in the gateway API, for fields that are not set.
@@ -39,11 +43,17 @@ func (b *ListenerBuilder) HTTP() *ListenerBuilder {
 
 func (b *ListenerBuilder) HTTPS() *ListenerBuilder {
 	b.listener.Protocol = gatewayv1beta1.HTTPSProtocolType
+	if b.listener.TLS == nil {
+		b.WithTLSTerminate()
+	}
 	return b
 }
 
 func (b *ListenerBuilder) TLS() *ListenerBuilder {
 	b.listener.Protocol = gatewayv1beta1.TLSProtocolType
+	if b.listener.TLS == nil {
+		b.WithTLSTerminate()
+	}
 	return b
 }
 
@@ -60,4 +70,108 @@ func (b *ListenerBuilder) WithHostname(hostname string) *ListenerBuilder {
 func (b *ListenerBuilder) WithAllowedRoutes(routes *gatewayv1beta1.AllowedRoutes) *ListenerBuilder {
 	b.listener.AllowedRoutes = routes
 	return b
-}
\ No newline at end of file
+}
+
+// AllowRoutesFromSameNamespace restricts the listener to only accept routes
+// from its own namespace.
+func (b *ListenerBuilder) AllowRoutesFromSameNamespace() *ListenerBuilder {
+	b.ensureAllowedRoutes().Namespaces = &gatewayv1beta1.RouteNamespaces{
+		From: addressOf(gatewayv1beta1.NamespacesFromSame),
+	}
+	return b
+}
+
+// AllowRoutesFromAll allows the listener to accept routes from any namespace.
+func (b *ListenerBuilder) AllowRoutesFromAll() *ListenerBuilder {
+	b.ensureAllowedRoutes().Namespaces = &gatewayv1beta1.RouteNamespaces{
+		From: addressOf(gatewayv1beta1.NamespacesFromAll),
+	}
+	return b
+}
+
+// AllowRoutesFromSelector allows the listener to accept routes from
+// namespaces matching the given selector.
+func (b *ListenerBuilder) AllowRoutesFromSelector(selector labels.Selector) *ListenerBuilder {
+	labelSelector, err := metav1.ParseToLabelSelector(selector.String())
+	if err != nil {
+		// selector.String() always produces a parseable selector expression,
+		// so this can only happen if labels.Selector's format ever diverges
+		// from metav1.LabelSelector's; fall back to an empty selector rather
+		// than panicking in a test helper.
+		labelSelector = &metav1.LabelSelector{}
+	}
+
+	b.ensureAllowedRoutes().Namespaces = &gatewayv1beta1.RouteNamespaces{
+		From:     addressOf(gatewayv1beta1.NamespacesFromSelector),
+		Selector: labelSelector,
+	}
+	return b
+}
+
+// AllowRouteKinds restricts the listener to only accept routes of the given
+// kinds. If From has not been set yet, it defaults to Same per the gateway
+// API spec.
+func (b *ListenerBuilder) AllowRouteKinds(kinds ...gatewayv1beta1.RouteGroupKind) *ListenerBuilder {
+	allowedRoutes := b.ensureAllowedRoutes()
+	if allowedRoutes.Namespaces == nil {
+		allowedRoutes.Namespaces = &gatewayv1beta1.RouteNamespaces{}
+	}
+	if allowedRoutes.Namespaces.From == nil {
+		allowedRoutes.Namespaces.From = addressOf(gatewayv1beta1.NamespacesFromSame)
+	}
+	allowedRoutes.Kinds = kinds
+	return b
+}
+
+// ensureAllowedRoutes returns the listener's AllowedRoutes, initializing it
+// if it has not been set yet.
+func (b *ListenerBuilder) ensureAllowedRoutes() *gatewayv1beta1.AllowedRoutes {
+	if b.listener.AllowedRoutes == nil {
+		b.listener.AllowedRoutes = &gatewayv1beta1.AllowedRoutes{}
+	}
+	return b.listener.AllowedRoutes
+}
+
+// WithTLSPassthrough configures the listener's TLS block with Mode set to
+// Passthrough, in which case certificates are not terminated by the Gateway
+// and TLS termination is left to the backend.
+func (b *ListenerBuilder) WithTLSPassthrough() *ListenerBuilder {
+	b.listener.TLS = &gatewayv1beta1.GatewayTLSConfig{
+		Mode: addressOf(gatewayv1beta1.TLSModePassthrough),
+	}
+	return b
+}
+
+// WithTLSTerminate configures the listener's TLS block with Mode set to
+// Terminate and CertificateRefs set to the given refs, so that the Gateway
+// terminates TLS using the referenced certificates.
+func (b *ListenerBuilder) WithTLSTerminate(certRefs ...gatewayv1beta1.SecretObjectReference) *ListenerBuilder {
+	b.listener.TLS = &gatewayv1beta1.GatewayTLSConfig{
+		Mode:            addressOf(gatewayv1beta1.TLSModeTerminate),
+		CertificateRefs: certRefs,
+	}
+	return b
+}
+
+// WithTLSOptions sets the Options field on the listener's TLS block,
+// initializing TLS with a default Terminate mode if it has not been set yet.
+func (b *ListenerBuilder) WithTLSOptions(options map[gatewayv1beta1.AnnotationKey]gatewayv1beta1.AnnotationValue) *ListenerBuilder {
+	if b.listener.TLS == nil {
+		b.WithTLSTerminate()
+	}
+	b.listener.TLS.Options = options
+	return b
+}
+
+// WithTLSConfig sets the listener's TLS block verbatim, as an escape hatch
+// for configurations not covered by the other TLS helpers.
+func (b *ListenerBuilder) WithTLSConfig(tls *gatewayv1beta1.GatewayTLSConfig) *ListenerBuilder {
+	b.listener.TLS = tls
+	return b
+}
+
+// addressOf returns a pointer to the given value, for use with gateway API
+// fields that expect optional pointer types.
+func addressOf[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,168 @@
+//go:build e2e_tests
+
+package perf
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// KongConfigurationApplyFailedReason is the Event reason emitted by
+// internal/dataplane/kong_client.go against an Ingress (or other supported
+// object) when sendconfig.PerformUpdate rejects the entity it produced.
+const KongConfigurationApplyFailedReason = "KongConfigurationApplyFailed"
+
+// ApplyFailureWatcher watches for KongConfigurationApplyFailedReason Events
+// in a namespace and records which involved objects were rejected, so a perf
+// run can fail fast on invalid configuration instead of hanging on
+// require.Eventually for an unlucky sample. If the underlying watch closes
+// early (e.g. an apiserver watch timeout or restart) it is transparently
+// re-established until Stop is called or ctx is done, so a transient
+// disconnect doesn't stop failure collection for the rest of the run. If
+// re-establishing the watch returns an error, collection stops; failures
+// recorded up to then remain available from Failures.
+type ApplyFailureWatcher struct {
+	ctx       context.Context
+	client    kubernetes.Interface
+	namespace string
+
+	mu       sync.Mutex
+	failures map[string]string // involved object name -> event message
+
+	watcherMu sync.Mutex
+	watcher   watch.Interface
+
+	stopped atomic.Bool
+	done    chan struct{}
+}
+
+// WatchApplyFailures starts watching Events in namespace for
+// KongConfigurationApplyFailedReason and returns a watcher that accumulates
+// them until Stop is called.
+func WatchApplyFailures(ctx context.Context, client kubernetes.Interface, namespace string) (*ApplyFailureWatcher, error) {
+	afw := &ApplyFailureWatcher{
+		ctx:       ctx,
+		client:    client,
+		namespace: namespace,
+		failures:  map[string]string{},
+		done:      make(chan struct{}),
+	}
+
+	w, err := afw.watch()
+	if err != nil {
+		return nil, err
+	}
+	afw.watcher = w
+
+	go afw.run()
+	return afw, nil
+}
+
+func (w *ApplyFailureWatcher) watch() (watch.Interface, error) {
+	watcher, err := w.client.CoreV1().Events(w.namespace).Watch(w.ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("reason=%s", KongConfigurationApplyFailedReason),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("watching %s events in namespace %s: %w", KongConfigurationApplyFailedReason, w.namespace, err)
+	}
+	return watcher, nil
+}
+
+func (w *ApplyFailureWatcher) run() {
+	defer close(w.done)
+	for {
+		w.watcherMu.Lock()
+		resultChan := w.watcher.ResultChan()
+		w.watcherMu.Unlock()
+
+		for event := range resultChan {
+			e, ok := event.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			w.mu.Lock()
+			w.failures[e.InvolvedObject.Name] = e.Message
+			w.mu.Unlock()
+		}
+
+		// The channel above only closes when the watch ends, whether because
+		// Stop was called or because the connection was dropped out from
+		// under us. In the latter case, reconnect so failures that occur
+		// after the drop are still observed; in the former, just exit.
+		if w.stopped.Load() || w.ctx.Err() != nil {
+			return
+		}
+
+		newWatcher, err := w.watch()
+		if err != nil {
+			return
+		}
+
+		// Stop() may have run concurrently with the reconnect above: it only
+		// ever stops w.watcher as it was when it acquired watcherMu, so if it
+		// ran between our stopped-check and here, newWatcher was never told
+		// to stop and this goroutine would range over it forever while Stop
+		// blocks on <-w.done. Re-check stopped inside the same critical
+		// section that publishes newWatcher so the two can't interleave.
+		w.watcherMu.Lock()
+		if w.stopped.Load() {
+			w.watcherMu.Unlock()
+			newWatcher.Stop()
+			return
+		}
+		w.watcher = newWatcher
+		w.watcherMu.Unlock()
+	}
+}
+
+// Stop stops the underlying watch and waits for the collector goroutine to
+// finish. It acquires watcherMu itself: setting stopped and stopping the
+// current watcher happen under the same lock that run() holds while
+// publishing a reconnected watcher, so a reconnect racing with Stop always
+// ends up stopping whichever watcher is current.
+func (w *ApplyFailureWatcher) Stop() {
+	w.watcherMu.Lock()
+	w.stopped.Store(true)
+	w.watcher.Stop()
+	w.watcherMu.Unlock()
+	<-w.done
+}
+
+// Failures returns the involved object names rejected so far, along with the
+// rejection message, sorted by object name for stable, readable test output.
+func (w *ApplyFailureWatcher) Failures() []ApplyFailure {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	failures := make([]ApplyFailure, 0, len(w.failures))
+	for name, message := range w.failures {
+		failures = append(failures, ApplyFailure{Name: name, Message: message})
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Name < failures[j].Name })
+	return failures
+}
+
+// ApplyFailure describes a single involved object rejected via
+// KongConfigurationApplyFailedReason.
+type ApplyFailure struct {
+	Name    string
+	Message string
+}
+
+// FormatApplyFailures renders a breakdown of the failures suitable for a
+// test failure message.
+func FormatApplyFailures(failures []ApplyFailure) string {
+	msg := fmt.Sprintf("%d object(s) rejected by Kong:\n", len(failures))
+	for _, f := range failures {
+		msg += fmt.Sprintf("  - %s: %s\n", f.Name, f.Message)
+	}
+	return msg
+}
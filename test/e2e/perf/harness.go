@@ -0,0 +1,315 @@
+//go:build e2e_tests
+
+// Package perf provides a reusable harness for the KIC's large-scale e2e
+// performance tests. It centralizes the bits that TestBasicPerf used to do
+// inline and ad hoc: configuring the scale of a run via env vars/flags,
+// recording per-phase latencies, computing percentiles, and exporting the
+// results as both a JSON report and a Prometheus textfile so CI can diff
+// across runs.
+package perf
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Configuration
+// -----------------------------------------------------------------------------
+
+// Shape describes what kind of objects a perf run generates.
+type Shape string
+
+const (
+	// ShapeHost generates one object per distinct hostname.
+	ShapeHost Shape = "host"
+	// ShapePath generates one object per distinct path on a shared hostname.
+	ShapePath Shape = "path"
+	// ShapeTLS generates one object per distinct hostname, each terminating TLS.
+	ShapeTLS Shape = "tls"
+)
+
+var (
+	flagN                   = flag.Int("perf.n", 10000, "number of objects (ingresses/routes) to create during a perf run")
+	flagConcurrency         = flag.Int("perf.concurrency", 50, "number of concurrent probes used while waiting for routes to become routable")
+	flagSampleSize          = flag.Int("perf.sample-size", 50, "number of objects sampled to compute time-to-first-200 percentiles")
+	flagShape               = flag.String("perf.shape", string(ShapeHost), "shape of generated objects: host, path, or tls")
+	flagOutputDir           = flag.String("perf.output-dir", "", "directory perf reports are written to; overridden by PERF_OUTPUT_DIR if set, and a temp dir (deleted when the test completes) if neither is set")
+	flagSteadyStateDuration = flag.Duration("perf.steady-state-duration", 10*time.Second, "how long to drive sustained concurrent requests against already-routable objects to measure steady-state RPS")
+)
+
+// Config parameterizes a perf run. Fields default from the perf.* test flags,
+// which in turn can be overridden by PERF_* environment variables so that CI
+// can tune a run without touching go test invocation flags.
+type Config struct {
+	// N is the number of objects (ingresses, HTTPRoutes, ...) to create.
+	N int
+	// Concurrency bounds how many probes run at once while waiting for routes
+	// to become routable.
+	Concurrency int
+	// SampleSize is how many of the N objects are sampled to compute
+	// time-to-first-200 percentiles; it is independent of N so that large
+	// runs don't require probing every single object.
+	SampleSize int
+	// Shape selects the kind of objects the run generates.
+	Shape Shape
+	// SteadyStateDuration is how long the steady-state phase drives sustained
+	// concurrent requests against the already-routable sampled objects.
+	SteadyStateDuration time.Duration
+}
+
+// ConfigFromFlags builds a Config from the perf.* test flags, applying
+// PERF_N / PERF_CONCURRENCY / PERF_SAMPLE_SIZE / PERF_SHAPE /
+// PERF_STEADY_STATE_DURATION environment overrides on top where present.
+func ConfigFromFlags() Config {
+	cfg := Config{
+		N:                   *flagN,
+		Concurrency:         *flagConcurrency,
+		SampleSize:          *flagSampleSize,
+		Shape:               Shape(*flagShape),
+		SteadyStateDuration: *flagSteadyStateDuration,
+	}
+	if v, ok := envInt("PERF_N"); ok {
+		cfg.N = v
+	}
+	if v, ok := envInt("PERF_CONCURRENCY"); ok {
+		cfg.Concurrency = v
+	}
+	if v, ok := envInt("PERF_SAMPLE_SIZE"); ok {
+		cfg.SampleSize = v
+	}
+	if v := os.Getenv("PERF_SHAPE"); v != "" {
+		cfg.Shape = Shape(v)
+	}
+	if v := os.Getenv("PERF_STEADY_STATE_DURATION"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.SteadyStateDuration = d
+		}
+	}
+	return cfg
+}
+
+// OutputDir returns the directory perf reports should be written to: the
+// perf.output-dir flag, or the PERF_OUTPUT_DIR environment variable if set
+// (env takes precedence, consistent with the other PERF_* overrides). If
+// neither is set, it falls back to t.TempDir() — fine for a local, one-off
+// run, but CI that wants to diff reports across runs must set one of the
+// above, since Go deletes a t.TempDir() in test Cleanup.
+func OutputDir(t *testing.T) string {
+	dir := *flagOutputDir
+	if v := os.Getenv("PERF_OUTPUT_DIR"); v != "" {
+		dir = v
+	}
+	if dir == "" {
+		return t.TempDir()
+	}
+	return dir
+}
+
+func envInt(name string) (int, bool) {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// SampleIndices returns max(k, 2) distinct indices in [0,n), sorted, always
+// including the first and last index so that off-by-one errors at the
+// boundaries of a run are caught; the remaining indices are chosen uniformly
+// at random. If k >= n every index in [0,n) is returned, and if k <= 0 none
+// are. This replaces the old getRandomList helper, which always returned a
+// fixed-size sample regardless of the run's size (for n <= 10, just 0 and
+// the out-of-range index n).
+func SampleIndices(n, k int) []int {
+	if n <= 0 {
+		return nil
+	}
+	if k >= n {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+	if k <= 0 {
+		return nil
+	}
+
+	picked := map[int]struct{}{0: {}, n - 1: {}}
+	for _, i := range rand.Perm(n) {
+		if len(picked) >= k {
+			break
+		}
+		picked[i] = struct{}{}
+	}
+
+	indices := make([]int, 0, len(picked))
+	for i := range picked {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// MeasureSteadyStateRPS drives sustained concurrent calls to probe across
+// concurrency workers for duration, once the objects probe exercises are
+// already known to be routable, and returns the number of successful calls
+// observed per second. probe should perform one request and report whether
+// it succeeded; it is called repeatedly and concurrently until duration
+// elapses or ctx is done.
+func MeasureSteadyStateRPS(ctx context.Context, duration time.Duration, concurrency int, probe func(ctx context.Context) bool) float64 {
+	deadline := time.Now().Add(duration)
+
+	var successCount int64
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+				if probe(ctx) {
+					atomic.AddInt64(&successCount, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return float64(successCount) / duration.Seconds()
+}
+
+// -----------------------------------------------------------------------------
+// Results
+// -----------------------------------------------------------------------------
+
+// Result holds the structured output of a single perf run.
+type Result struct {
+	Config Config `json:"config"`
+
+	// ApplyLatency is how long it took the apply step (e.g. `kubectl apply`)
+	// to return.
+	ApplyLatency time.Duration `json:"applyLatencyNs"`
+	// TimeToFirstRoute is the smallest of the TimeToFirst200 samples: how
+	// long the quickest sampled object took to return its first 200, measured
+	// from the start of its own probe. Probing begins only after apply has
+	// returned and the proxy address is known, so that wait is not included.
+	TimeToFirstRoute time.Duration `json:"timeToFirstRouteNs"`
+	// TimeToFirst200 holds the P50/P90/P99 latency, across the sampled
+	// objects, of the first successful request to each object.
+	TimeToFirst200 Percentiles `json:"timeToFirst200Ns"`
+	// ProvisioningRPS is how many of the sampled objects became routable per
+	// second, i.e. len(sampled)/wallClockUntilAllSampledObjectsAreRoutable.
+	// This is a provisioning throughput metric, not a measure of sustained
+	// request throughput against already-routable objects.
+	ProvisioningRPS float64 `json:"provisioningRPS"`
+	// SteadyStateRPS is the sustained request throughput achieved against the
+	// already-routable sampled objects, measured by MeasureSteadyStateRPS
+	// over Config.SteadyStateDuration.
+	SteadyStateRPS float64 `json:"steadyStateRPS"`
+}
+
+// Percentiles holds P50/P90/P99 latency samples.
+type Percentiles struct {
+	P50 time.Duration `json:"p50Ns"`
+	P90 time.Duration `json:"p90Ns"`
+	P99 time.Duration `json:"p99Ns"`
+}
+
+// ComputePercentiles returns the P50/P90/P99 of the given durations. The
+// input slice is sorted in place.
+func ComputePercentiles(samples []time.Duration) Percentiles {
+	if len(samples) == 0 {
+		return Percentiles{}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return Percentiles{
+		P50: percentile(samples, 0.50),
+		P90: percentile(samples, 0.90),
+		P99: percentile(samples, 0.99),
+	}
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+// WriteJSONReport writes the result as JSON to path, for tooling that wants
+// to diff full results across CI runs.
+func WriteJSONReport(path string, result Result) error {
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling perf result: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("writing perf JSON report to %s: %w", path, err)
+	}
+	return nil
+}
+
+// WritePrometheusTextfile writes the result in the node_exporter textfile
+// collector format, so dashboards can track perf runs over time alongside
+// other fleet metrics.
+//
+// The time-to-first-200 percentiles are exposed as plain gauges, one per
+// quantile, rather than a Prometheus "summary" — a summary additionally
+// requires `_sum`/`_count` series, which we don't have (we only ever compute
+// three fixed quantiles from a single run, not a running distribution), and
+// an incomplete summary is malformed enough that node_exporter's textfile
+// collector rejects the whole file.
+func WritePrometheusTextfile(path string, result Result) error {
+	metrics := fmt.Sprintf(
+		`# HELP kic_perf_apply_latency_seconds Time for the apply step to return.
+# TYPE kic_perf_apply_latency_seconds gauge
+kic_perf_apply_latency_seconds{shape=%q} %f
+# HELP kic_perf_time_to_first_route_seconds Time until the first object became routable.
+# TYPE kic_perf_time_to_first_route_seconds gauge
+kic_perf_time_to_first_route_seconds{shape=%q} %f
+# HELP kic_perf_time_to_first_200_seconds Time to first successful request per sampled object, at a fixed quantile.
+# TYPE kic_perf_time_to_first_200_seconds gauge
+kic_perf_time_to_first_200_seconds{shape=%q,quantile="0.5"} %f
+kic_perf_time_to_first_200_seconds{shape=%q,quantile="0.9"} %f
+kic_perf_time_to_first_200_seconds{shape=%q,quantile="0.99"} %f
+# HELP kic_perf_provisioning_rps Sampled objects provisioned (first 200) per second, wall-clock, once apply returned. Not a sustained request throughput measurement.
+# TYPE kic_perf_provisioning_rps gauge
+kic_perf_provisioning_rps{shape=%q} %f
+# HELP kic_perf_steady_state_rps Sustained successful requests per second against already-routable sampled objects, measured over Config.SteadyStateDuration.
+# TYPE kic_perf_steady_state_rps gauge
+kic_perf_steady_state_rps{shape=%q} %f
+`,
+		result.Config.Shape, result.ApplyLatency.Seconds(),
+		result.Config.Shape, result.TimeToFirstRoute.Seconds(),
+		result.Config.Shape, result.TimeToFirst200.P50.Seconds(),
+		result.Config.Shape, result.TimeToFirst200.P90.Seconds(),
+		result.Config.Shape, result.TimeToFirst200.P99.Seconds(),
+		result.Config.Shape, result.ProvisioningRPS,
+		result.Config.Shape, result.SteadyStateRPS,
+	)
+	if err := os.WriteFile(path, []byte(metrics), 0o644); err != nil {
+		return fmt.Errorf("writing perf prometheus textfile to %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+//go:build e2e_tests
+
+package perf
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies spans emitted by the perf harness, distinguishing
+// them from the KIC's own traces when both are shipped to the same backend.
+const tracerName = "github.com/kong/kubernetes-ingress-controller/v3/test/e2e/perf"
+
+// NewTracerProvider builds a TracerProvider that exports spans to the OTLP
+// endpoint named by the OTEL_EXPORTER_OTLP_ENDPOINT environment variable.
+// If that variable is unset, it returns a no-op provider so that tests can
+// unconditionally call StartSpan without checking whether tracing is enabled.
+func NewTracerProvider(ctx context.Context) (trace.TracerProvider, func(context.Context) error, error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return otel.GetTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(
+		semconv.ServiceNameKey.String("kic-perf-test"),
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating OTEL resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	return tp, tp.Shutdown, nil
+}
+
+// StartSpan starts a span named name using the given TracerProvider, scoped
+// to the perf harness's own tracer. Callers should defer span.End().
+func StartSpan(ctx context.Context, tp trace.TracerProvider, name string) (context.Context, trace.Span) {
+	return tp.Tracer(tracerName).Start(ctx, name)
+}
@@ -4,6 +4,7 @@ package e2e
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -14,7 +15,9 @@ import (
 	"time"
 
 	"github.com/kong/kubernetes-ingress-controller/v3/test"
+	"github.com/kong/kubernetes-ingress-controller/v3/test/e2e/perf"
 	"github.com/kong/kubernetes-ingress-controller/v3/test/internal/helpers"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -25,21 +28,31 @@ import (
 // E2E Performance tests
 // -----------------------------------------------------------------------------
 
-// TestBasicHTTPRoute will create a basic HTTP route and test its functionality
-// against a Kong proxy. This test will be used to measure the performance of
-// the KIC with OpenTelemetry.
+// TestBasicPerf creates a large number of Ingress rules and measures, via the
+// test/e2e/perf harness, how long the KIC takes to pick them up and make them
+// routable through Kong. Scale and shape are controlled by the perf.* flags /
+// PERF_* env vars (see test/e2e/perf), and results are exported as a JSON
+// report and a Prometheus textfile so CI can diff across runs.
 func TestBasicPerf(t *testing.T) {
 	t.Log("configuring all-in-one-dbless.yaml manifest test")
 	t.Parallel()
 	ctx, env := setupE2ETest(t)
 
+	cfg := perf.ConfigFromFlags()
+
+	tp, shutdownTracing, err := perf.NewTracerProvider(ctx)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, shutdownTracing(ctx))
+	}()
+
 	t.Log("deploying kong components")
 	ManifestDeploy{Path: dblessPath}.Run(ctx, t, env)
 
 	t.Log("deploying a minimal HTTP container deployment to test Ingress routes")
 	container := generators.NewContainer("httpbin", test.HTTPBinImage, test.HTTPBinPort)
 	deployment := generators.NewDeploymentForContainer(container)
-	deployment, err := env.Cluster().Client().AppsV1().Deployments("default").Create(ctx, deployment, metav1.CreateOptions{})
+	deployment, err = env.Cluster().Client().AppsV1().Deployments("default").Create(ctx, deployment, metav1.CreateOptions{})
 	require.NoError(t, err)
 
 	t.Logf("exposing deployment %s via service", deployment.Name)
@@ -47,9 +60,89 @@ func TestBasicPerf(t *testing.T) {
 	_, err = env.Cluster().Client().CoreV1().Services("default").Create(ctx, service, metav1.CreateOptions{})
 	require.NoError(t, err)
 
-	// I want to to create a large YAML file,
-	// it includes 1000 ingress rules, every rule has a different host name and path.
-	ingressTpl := `
+	if cfg.Shape == perf.ShapeTLS {
+		t.Log("provisioning perf-tls-cert Secret for TLS-terminating ingresses")
+		provisionPerfTLSSecret(ctx, t, env, cfg)
+	}
+
+	ingressYaml := generateIngressYAML(cfg)
+
+	applyCtx, applySpan := perf.StartSpan(ctx, tp, "apply")
+	kubeconfig := getTemporaryKubeconfig(t, env)
+	t1 := time.Now()
+	cmd := exec.CommandContext(applyCtx, "kubectl", "--kubeconfig", kubeconfig, "apply", "-f", "-")
+	cmd.Stdin = strings.NewReader(ingressYaml)
+	_, err = cmd.CombinedOutput()
+	require.NoError(t, err)
+	applyLatency := time.Since(t1)
+	applySpan.End()
+
+	t.Log("getting kong proxy IP after LB provisioning")
+	proxyURLForDefaultIngress := "http://" + getKongProxyIP(ctx, t, env)
+
+	t.Log("watching for KongConfigurationApplyFailed events on involved ingresses")
+	failureWatcher, err := perf.WatchApplyFailures(ctx, env.Cluster().Client(), "default")
+	require.NoError(t, err)
+	defer failureWatcher.Stop()
+
+	t.Log("waiting for routes from Ingress to be operational")
+	waitCtx, waitSpan := perf.StartSpan(ctx, tp, "wait-for-routes")
+
+	sampled := perf.SampleIndices(cfg.N, cfg.SampleSize)
+	t2 := time.Now()
+	latencies := waitForRoutesOrApplyFailure(waitCtx, t, proxyURLForDefaultIngress, cfg, sampled, failureWatcher)
+	provisioningDuration := time.Since(t2)
+	waitSpan.End()
+
+	var timeToFirstRoute time.Duration
+	for _, l := range latencies {
+		if timeToFirstRoute == 0 || l < timeToFirstRoute {
+			timeToFirstRoute = l
+		}
+	}
+
+	t.Log("driving sustained requests against already-routable ingresses to measure steady-state RPS")
+	steadyCtx, steadySpan := perf.StartSpan(ctx, tp, "steady-state")
+	steadyStateRPS := perf.MeasureSteadyStateRPS(steadyCtx, cfg.SteadyStateDuration, cfg.Concurrency, func(ctx context.Context) bool {
+		i := sampled[rand.Intn(len(sampled))]
+		host, path := ingressHostAndPath(cfg.Shape, i)
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s", proxyURLForDefaultIngress, path), nil)
+		if err != nil {
+			return false
+		}
+		req.Host = host
+		resp, err := helpers.DefaultHTTPClient().Do(req)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	})
+	steadySpan.End()
+
+	result := perf.Result{
+		Config:           cfg,
+		ApplyLatency:     applyLatency,
+		TimeToFirstRoute: timeToFirstRoute,
+		TimeToFirst200:   perf.ComputePercentiles(latencies),
+		ProvisioningRPS:  float64(len(sampled)) / provisioningDuration.Seconds(),
+		SteadyStateRPS:   steadyStateRPS,
+	}
+
+	t.Logf("time to apply %d ingress rules: %v", cfg.N, applyLatency)
+	t.Logf("time to make %d ingress rules take effect (sampled %d): %v", cfg.N, len(sampled), provisioningDuration)
+	t.Logf("steady-state RPS against %d sampled ingresses over %v: %f", len(sampled), cfg.SteadyStateDuration, steadyStateRPS)
+
+	outputDir := perf.OutputDir(t)
+	require.NoError(t, perf.WriteJSONReport(fmt.Sprintf("%s/perf-basic.json", outputDir), result))
+	require.NoError(t, perf.WritePrometheusTextfile(fmt.Sprintf("%s/perf-basic.prom", outputDir), result))
+}
+
+// generateIngressYAML builds cfg.N Ingress manifests, varying by hostname,
+// path, or TLS termination depending on cfg.Shape, concatenated into a
+// single multi-document YAML stream suitable for `kubectl apply -f -`.
+func generateIngressYAML(cfg perf.Config) string {
+	const ingressTpl = `
 ---
 apiVersion: networking.k8s.io/v1
 kind: Ingress
@@ -57,8 +150,8 @@ metadata:
   name: test-ingress-%d
 spec:
   ingressClassName: kong
-  rules:
-  - host: example-%d.com
+%s  rules:
+  - host: %s
     http:
       paths:
       - backend:
@@ -66,52 +159,138 @@ spec:
             name: httpbin
             port:
               number: 80
-        path: /get
+        path: %s
         pathType: Exact
 
 `
 
-	ingressYaml := ""
-	for i := 0; i < 10000; i++ {
-		ingressYaml += fmt.Sprintf(ingressTpl, i, i)
+	var b strings.Builder
+	for i := 0; i < cfg.N; i++ {
+		host, path := ingressHostAndPath(cfg.Shape, i)
+		fmt.Fprintf(&b, ingressTpl, i, tlsBlockForShape(cfg.Shape, host), host, path)
 	}
+	return b.String()
+}
 
-	t1 := time.Now()
-	// use kubectl apply the ingressYAML to kubernetes
-	kubeconfig := getTemporaryKubeconfig(t, env)
-	cmd := exec.CommandContext(ctx, "kubectl", "--kubeconfig", kubeconfig, "apply", "-f", "-")
-	cmd.Stdin = strings.NewReader(ingressYaml)
-	_, err = cmd.CombinedOutput()
+// ingressHostAndPath returns the host and path an Ingress at index i should
+// use for the given shape:
+//   - ShapeHost varies the hostname per object and keeps a constant path.
+//   - ShapePath shares a single hostname and varies the path per object.
+//   - ShapeTLS varies the hostname per object, like ShapeHost, but the
+//     Ingress additionally terminates TLS (see tlsBlockForShape).
+func ingressHostAndPath(shape perf.Shape, i int) (host, path string) {
+	switch shape {
+	case perf.ShapePath:
+		return "perf.example.com", fmt.Sprintf("/get-%d", i)
+	case perf.ShapeTLS:
+		return fmt.Sprintf("example-%d.com", i), "/get"
+	case perf.ShapeHost:
+		fallthrough
+	default:
+		return fmt.Sprintf("example-%d.com", i), "/get"
+	}
+}
+
+// tlsBlockForShape returns the `tls:` stanza for an Ingress spec when shape
+// is ShapeTLS, terminating against the perf-tls-cert Secret provisioned by
+// provisionPerfTLSSecret; it returns an empty string for every other shape.
+func tlsBlockForShape(shape perf.Shape, host string) string {
+	if shape != perf.ShapeTLS {
+		return ""
+	}
+	return fmt.Sprintf("  tls:\n  - hosts:\n    - %s\n    secretName: perf-tls-cert\n", host)
+}
+
+// provisionPerfTLSSecret creates the perf-tls-cert Secret that ShapeTLS
+// ingresses reference in their `tls:` stanza (see tlsBlockForShape), using a
+// throwaway self-signed cert covering every hostname the run generates.
+// Kong only needs something to terminate with; the cert's validity isn't
+// otherwise exercised by this test.
+func provisionPerfTLSSecret(ctx context.Context, t *testing.T, env clusters.Environment, cfg perf.Config) {
+	t.Helper()
+
+	hosts := make([]string, 0, cfg.N)
+	for i := 0; i < cfg.N; i++ {
+		host, _ := ingressHostAndPath(perf.ShapeTLS, i)
+		hosts = append(hosts, host)
+	}
+
+	certPEM, keyPEM, err := perf.GenerateSelfSignedCert(hosts...)
 	require.NoError(t, err)
-	t2 := time.Now()
 
-	t.Log("getting kong proxy IP after LB provisioning")
-	proxyURLForDefaultIngress := "http://" + getKongProxyIP(ctx, t, env)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "perf-tls-cert"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	_, err = env.Cluster().Client().CoreV1().Secrets("default").Create(ctx, secret, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
 
-	t.Log("waiting for routes from Ingress to be operational")
+// waitForRoutesOrApplyFailure runs probeIngresses in the background while
+// polling failureWatcher; if any object watched by failureWatcher is rejected
+// via a KongConfigurationApplyFailedReason event, whether sampled or not, the
+// test fails immediately with a breakdown of the rejected objects instead of
+// waiting out the full require.Eventually timeout for a sample that can never
+// succeed.
+func waitForRoutesOrApplyFailure(ctx context.Context, t *testing.T, proxyURL string, cfg perf.Config, sampled []int, failureWatcher *perf.ApplyFailureWatcher) []time.Duration {
+	t.Helper()
+
+	probeDone := make(chan []time.Duration, 1)
+	go func() {
+		probeDone <- probeIngresses(ctx, t, proxyURL, cfg, sampled)
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case latencies := <-probeDone:
+			return latencies
+		case <-ticker.C:
+			if failures := failureWatcher.Failures(); len(failures) > 0 {
+				t.Fatalf("perf run aborted early: %s", perf.FormatApplyFailures(failures))
+			}
+		}
+	}
+}
+
+// probeIngresses concurrently polls the given sampled hosts/paths (computed
+// per cfg.Shape, matching generateIngressYAML) until each returns a 200 from
+// httpbin, and returns the latency of the first successful request for each
+// sampled object.
+func probeIngresses(ctx context.Context, t *testing.T, proxyURL string, cfg perf.Config, sampled []int) []time.Duration {
+	t.Helper()
 
-	// create wait group to wait for all ingress rules to take effect
-	randomList := getRandomList(10000)
+	latencies := make([]time.Duration, len(sampled))
+	sem := make(chan struct{}, cfg.Concurrency)
 	var wg sync.WaitGroup
-	wg.Add(len(randomList))
+	wg.Add(len(sampled))
 
-	for _, i := range randomList {
-		go func(i int) {
+	for idx, i := range sampled {
+		go func(idx, i int) {
 			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
 
-			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/get", proxyURLForDefaultIngress), nil)
+			host, path := ingressHostAndPath(cfg.Shape, i)
+			start := time.Now()
+			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s", proxyURL, path), nil)
 			require.NoError(t, err)
-			req.Host = fmt.Sprintf("example-%d.com", i)
+			req.Host = host
 
 			require.Eventually(t, func() bool {
 				resp, err := helpers.DefaultHTTPClient().Do(req)
 				if err != nil {
-					t.Logf("WARNING: error while waiting for %s: %v", proxyURLForDefaultIngress, err)
+					t.Logf("WARNING: error while waiting for %s: %v", proxyURL, err)
 					return false
 				}
 				defer resp.Body.Close()
 				if resp.StatusCode == http.StatusOK {
-					// now that the ingress backend is routable
 					b := new(bytes.Buffer)
 					n, err := b.ReadFrom(resp.Body)
 					require.NoError(t, err)
@@ -120,24 +299,11 @@ spec:
 				}
 				return false
 			}, ingressWait, time.Millisecond*500)
-		}(i)
-	}
-
-	wg.Wait()
-
-	t4 := time.Now()
 
-	t.Logf("time to apply 10000 ingress rules: %v", t2.Sub(t1))
-	t.Logf("time to make 10000 ingress rules take effect: %v", t4.Sub(t2))
-}
-
-func getRandomList(n int) []int {
-	if n <= 10 {
-		return []int{0, n}
+			latencies[idx] = time.Since(start)
+		}(idx, i)
 	}
-	randPerm := rand.Perm(n)
-	randPerm = randPerm[:10]
-	randPerm = append(randPerm, 0, n-1)
 
-	return randPerm
+	wg.Wait()
+	return latencies
 }
@@ -0,0 +1,205 @@
+//go:build e2e_tests
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kong/kubernetes-ingress-controller/v3/internal/util/builder"
+	"github.com/kong/kubernetes-ingress-controller/v3/test"
+	"github.com/kong/kubernetes-ingress-controller/v3/test/e2e/perf"
+	"github.com/kong/kubernetes-ingress-controller/v3/test/internal/helpers"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+)
+
+// TestBasicPerfGatewayAPI is the Gateway API sibling of TestBasicPerf. It
+// provisions a GatewayClass, a Gateway, and cfg.N HTTPRoutes with distinct
+// hostnames or paths (per cfg.Shape) pointing at the same backend Service, and measures the same
+// phases via the test/e2e/perf harness. This exercises the translator code
+// path that HTTPRoute is converging on, which differs from the Ingress path
+// in namespaced ParentRefs, per-route status writes, and CombinedRoutes name
+// generation at scale.
+func TestBasicPerfGatewayAPI(t *testing.T) {
+	t.Log("configuring all-in-one-dbless.yaml manifest test")
+	t.Parallel()
+	ctx, env := setupE2ETest(t)
+
+	cfg := perf.ConfigFromFlags()
+
+	tp, shutdownTracing, err := perf.NewTracerProvider(ctx)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, shutdownTracing(ctx))
+	}()
+
+	t.Log("deploying kong components")
+	ManifestDeploy{Path: dblessPath}.Run(ctx, t, env)
+
+	gwClient, err := gatewayAPIClient(env)
+	require.NoError(t, err)
+
+	t.Log("deploying a minimal HTTP container deployment to back the HTTPRoutes")
+	container := generators.NewContainer("httpbin", test.HTTPBinImage, test.HTTPBinPort)
+	deployment := generators.NewDeploymentForContainer(container)
+	deployment, err = env.Cluster().Client().AppsV1().Deployments("default").Create(ctx, deployment, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	t.Logf("exposing deployment %s via service", deployment.Name)
+	service := generators.NewServiceForDeployment(deployment, corev1.ServiceTypeLoadBalancer)
+	_, err = env.Cluster().Client().CoreV1().Services("default").Create(ctx, service, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	t.Log("provisioning GatewayClass and Gateway")
+	gatewayClassName := "kong-perf"
+	_, err = gwClient.GatewayV1beta1().GatewayClasses().Create(ctx, &gatewayv1beta1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: gatewayClassName},
+		Spec: gatewayv1beta1.GatewayClassSpec{
+			ControllerName: gatewayv1beta1.GatewayController("konghq.com/kic-gateway-controller"),
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	gateway := &gatewayv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "kong-perf"},
+		Spec: gatewayv1beta1.GatewaySpec{
+			GatewayClassName: gatewayv1beta1.ObjectName(gatewayClassName),
+			Listeners: builder.NewListener("http").
+				HTTP().
+				WithPort(80).
+				IntoSlice(),
+		},
+	}
+	_, err = gwClient.GatewayV1beta1().Gateways("default").Create(ctx, gateway, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	t.Log("watching for KongConfigurationApplyFailed events on involved HTTPRoutes")
+	failureWatcher, err := perf.WatchApplyFailures(ctx, env.Cluster().Client(), "default")
+	require.NoError(t, err)
+	defer failureWatcher.Stop()
+
+	t1 := time.Now()
+	applyCtx, applySpan := perf.StartSpan(ctx, tp, "apply")
+	applyHTTPRoutes(applyCtx, t, gwClient, cfg, gateway.Name, service.Name)
+	applyLatency := time.Since(t1)
+	applySpan.End()
+
+	t.Log("getting kong proxy IP after LB provisioning")
+	proxyURLForDefaultIngress := "http://" + getKongProxyIP(ctx, t, env)
+
+	t.Log("waiting for routes from HTTPRoute to be operational")
+	waitCtx, waitSpan := perf.StartSpan(ctx, tp, "wait-for-routes")
+
+	sampled := perf.SampleIndices(cfg.N, cfg.SampleSize)
+	t2 := time.Now()
+	latencies := waitForRoutesOrApplyFailure(waitCtx, t, proxyURLForDefaultIngress, cfg, sampled, failureWatcher)
+	provisioningDuration := time.Since(t2)
+	waitSpan.End()
+
+	var timeToFirstRoute time.Duration
+	for _, l := range latencies {
+		if timeToFirstRoute == 0 || l < timeToFirstRoute {
+			timeToFirstRoute = l
+		}
+	}
+
+	t.Log("driving sustained requests against already-routable HTTPRoutes to measure steady-state RPS")
+	steadyCtx, steadySpan := perf.StartSpan(ctx, tp, "steady-state")
+	steadyStateRPS := perf.MeasureSteadyStateRPS(steadyCtx, cfg.SteadyStateDuration, cfg.Concurrency, func(ctx context.Context) bool {
+		i := sampled[rand.Intn(len(sampled))]
+		host, path := ingressHostAndPath(cfg.Shape, i)
+		req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s%s", proxyURLForDefaultIngress, path), nil)
+		if err != nil {
+			return false
+		}
+		req.Host = host
+		resp, err := helpers.DefaultHTTPClient().Do(req)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	})
+	steadySpan.End()
+
+	result := perf.Result{
+		Config:           cfg,
+		ApplyLatency:     applyLatency,
+		TimeToFirstRoute: timeToFirstRoute,
+		TimeToFirst200:   perf.ComputePercentiles(latencies),
+		ProvisioningRPS:  float64(len(sampled)) / provisioningDuration.Seconds(),
+		SteadyStateRPS:   steadyStateRPS,
+	}
+
+	t.Logf("time to apply %d HTTPRoutes: %v", cfg.N, applyLatency)
+	t.Logf("time to make %d HTTPRoutes take effect (sampled %d): %v", cfg.N, len(sampled), provisioningDuration)
+	t.Logf("steady-state RPS against %d sampled HTTPRoutes over %v: %f", len(sampled), cfg.SteadyStateDuration, steadyStateRPS)
+
+	outputDir := perf.OutputDir(t)
+	require.NoError(t, perf.WriteJSONReport(fmt.Sprintf("%s/perf-gateway-api.json", outputDir), result))
+	require.NoError(t, perf.WritePrometheusTextfile(fmt.Sprintf("%s/perf-gateway-api.prom", outputDir), result))
+}
+
+// applyHTTPRoutes creates cfg.N HTTPRoutes, each with the hostname and path
+// prefix that ingressHostAndPath gives for cfg.Shape and a ParentRef to
+// gatewayName, routing to serviceName. ShapeTLS routes are generated like
+// ShapeHost ones: the Gateway has only an HTTP listener, so no TLS is
+// terminated for them. Creates are fanned out
+// across a pool of cfg.Concurrency goroutines, rather than issued one at a
+// time, so the measured apply latency reflects server/translator cost
+// instead of being dominated by serial client round-trips — comparable to
+// TestBasicPerf's single `kubectl apply` stream.
+func applyHTTPRoutes(ctx context.Context, t *testing.T, gwClient gatewayclient.Interface, cfg perf.Config, gatewayName, serviceName string) {
+	t.Helper()
+
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	for i := 0; i < cfg.N; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			host, path := ingressHostAndPath(cfg.Shape, i)
+			route := builder.NewHTTPRoute(fmt.Sprintf("test-httproute-%d", i)).
+				WithNamespace("default").
+				WithHostnames(host).
+				WithParentRef(gatewayName).
+				WithRule(builder.NewHTTPRouteRule().
+					WithMatch(builder.NewHTTPRouteMatch().WithPathPrefix(path).Build()).
+					WithBackendRef(builder.NewHTTPBackendRef(serviceName).WithPort(80).Build()).
+					Build()).
+				Build()
+
+			if _, err := gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, route, metav1.CreateOptions{}); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("creating %s: %w", route.Name, err))
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	require.NoError(t, errors.Join(errs...))
+}
+
+// gatewayAPIClient builds a Gateway API clientset for the test cluster.
+func gatewayAPIClient(env clusters.Environment) (gatewayclient.Interface, error) {
+	return gatewayclient.NewForConfig(env.Cluster().Config())
+}